@@ -0,0 +1,163 @@
+// Command interfacer generates Go interface declarations from the method
+// sets of concrete types.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/rjeczalik/interfaces"
+	"github.com/rjeczalik/interfaces/config"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("interfacer: ")
+
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var (
+		forFlag    = flag.String("for", "", `"path/to/package.Type" to generate the interface from`)
+		asFlag     = flag.String("as", "", "name of the generated interface")
+		pkgFlag    = flag.String("p", "", "name of the package the interface is written to")
+		configFlag = flag.String("config", "", "path to an interfaces.yaml/.json config file describing every target to generate")
+	)
+	flag.Parse()
+
+	if *configFlag != "" {
+		if err := runConfig(*configFlag); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *forFlag == "" || *asFlag == "" {
+		fmt.Fprintln(os.Stderr, "usage: interfacer -for path/to/package.Type -as Interface [-p package] | -config interfaces.yaml | diff ...")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	query, err := interfaces.ParseQuery(*forFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	iface, err := interfaces.NewInterface(&interfaces.Options{
+		Query:       query,
+		PackageName: *pkgFlag,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	iface.Name = *asFlag
+
+	fmt.Println(iface)
+}
+
+// runDiff implements the "interfacer diff" subcommand, which checks a
+// generated interface for breaking API changes against a baseline file of
+// canonical method signatures written by a previous run. It accepts the
+// same -p and -config inputs as plain generation, so a project using a
+// config's models bindings or output package gets the same canonical
+// signatures out of diff as it does out of the generator.
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	var (
+		forFlag          = fs.String("for", "", `"path/to/package.Type" to generate the interface from`)
+		baselineFlag     = fs.String("baseline", "", "path to the baseline file of canonical method signatures")
+		writeFlag        = fs.Bool("write", false, "write the current interface's signatures to -baseline instead of diffing against it")
+		allowBreakingFlg = fs.Bool("allow-breaking", false, "exit 0 even if the diff contains breaking changes")
+		pkgFlag          = fs.String("p", "", "name of the package the interface is written to")
+		configFlag       = fs.String("config", "", "path to an interfaces.yaml/.json config file to source -p and models bindings from")
+	)
+	fs.Parse(args)
+
+	if *forFlag == "" || *baselineFlag == "" {
+		return fmt.Errorf("usage: interfacer diff -for path/to/package.Type -baseline file.txt [-p package] [-config file] [-write] [-allow-breaking]")
+	}
+
+	query, err := interfaces.ParseQuery(*forFlag)
+	if err != nil {
+		return err
+	}
+
+	opts := &interfaces.Options{Query: query, PackageName: *pkgFlag}
+	if *configFlag != "" {
+		cfg, err := config.Load(*configFlag)
+		if err != nil {
+			return err
+		}
+		opts.Aliases = cfg.Models
+		for _, t := range cfg.Targets {
+			if t.Source == *forFlag {
+				opts.PackageName = t.Package
+				break
+			}
+		}
+	}
+
+	iface, err := interfaces.NewInterface(opts)
+	if err != nil {
+		return err
+	}
+
+	if *writeFlag {
+		return interfaces.WriteBaseline(*baselineFlag, iface.Funcs)
+	}
+
+	baseline, err := interfaces.LoadBaseline(*baselineFlag)
+	if err != nil {
+		return err
+	}
+
+	report := interfaces.Diff(baseline, iface.Funcs)
+	fmt.Print(report)
+
+	if report.Breaking() && !*allowBreakingFlg {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// runConfig generates every target declared in the config file at path,
+// writing each one to its Output file, or to standard output for a target
+// with no Output.
+func runConfig(path string) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range cfg.Targets {
+		query, err := interfaces.ParseQuery(t.Source)
+		if err != nil {
+			return fmt.Errorf("target %q: %w", t.Name, err)
+		}
+
+		iface, err := interfaces.NewInterface(&interfaces.Options{
+			Query:       query,
+			PackageName: t.Package,
+			Aliases:     cfg.Models,
+		})
+		if err != nil {
+			return fmt.Errorf("target %q: %w", t.Name, err)
+		}
+		iface.Name = t.Name
+
+		if t.Output == "" {
+			fmt.Println(iface)
+			continue
+		}
+		if err := os.WriteFile(t.Output, []byte(fmt.Sprintf("package %s\n\n%s\n", t.Package, iface)), 0o644); err != nil {
+			return fmt.Errorf("target %q: writing %s: %w", t.Name, t.Output, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Package config implements loading of interfacer's declarative
+// configuration file.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Target describes a single interface to generate.
+type Target struct {
+	Name    string `yaml:"name" json:"name"`       // name of the generated interface, e.g. "ClientInterface"
+	Source  string `yaml:"source" json:"source"`   // "path/to/package.Type" query identifying the concrete type
+	Package string `yaml:"package" json:"package"` // name of the package the interface is written to
+	Output  string `yaml:"output" json:"output"`   // path of the file the interface is written to
+}
+
+// Config is the root of an interfaces.yaml (or .json) file. It lets a
+// project declare every interface it generates, plus a shared set of
+// models bindings, in one place.
+type Config struct {
+	Targets []Target `yaml:"targets" json:"targets"`
+
+	// Models binds the import path of an external package to the name its
+	// types are qualified with in generated code, e.g.
+	// "github.com/foo/bar": "shortpkg" renders bar.LongName as
+	// shortpkg.LongName. Keys are matched against package import paths
+	// only: a key naming a single type, such as
+	// "github.com/foo/bar.LongName", matches nothing and renames nothing.
+	Models map[string]string `yaml:"models" json:"models"`
+}
+
+// Load reads and parses a Config from path. The format is chosen from the
+// file extension: ".json" is decoded as JSON, anything else (".yaml",
+// ".yml", or no extension) is decoded as YAML.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	var cfg Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+		}
+	}
+
+	for i, t := range cfg.Targets {
+		if t.Name == "" {
+			return nil, fmt.Errorf("config: %s: targets[%d]: name is required", path, i)
+		}
+		if t.Source == "" {
+			return nil, fmt.Errorf("config: %s: targets[%d]: source is required", path, i)
+		}
+		if t.Output != "" && t.Package == "" {
+			return nil, fmt.Errorf("config: %s: targets[%d]: package is required when output is set", path, i)
+		}
+	}
+
+	return &cfg, nil
+}
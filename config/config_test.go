@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "interfaces.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `
+targets:
+  - name: ClientInterface
+    source: github.com/go-redis/redis.Client
+    package: mocks
+    output: mocks/client.go
+models:
+  github.com/go-redis/redis.Client: redis.Client
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Targets) != 1 || cfg.Targets[0].Name != "ClientInterface" {
+		t.Fatalf("Targets = %+v", cfg.Targets)
+	}
+	if cfg.Models["github.com/go-redis/redis.Client"] != "redis.Client" {
+		t.Fatalf("Models = %+v", cfg.Models)
+	}
+}
+
+func TestLoadMissingName(t *testing.T) {
+	path := writeConfig(t, `targets: [{source: foo.Bar}]`)
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() = nil error, want one for a target missing name")
+	}
+}
+
+func TestLoadMissingSource(t *testing.T) {
+	path := writeConfig(t, `targets: [{name: Foo}]`)
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() = nil error, want one for a target missing source")
+	}
+}
+
+func TestLoadOutputWithoutPackage(t *testing.T) {
+	path := writeConfig(t, `targets: [{name: Foo, source: foo.Bar, output: foo.go}]`)
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() = nil error, want one for an output target missing package")
+	}
+}
@@ -0,0 +1,138 @@
+package interfaces
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Report is the result of comparing two method sets produced by Diff: a
+// method can be added (non-breaking), removed, or changed (both breaking).
+type Report struct {
+	Added   []Func   `json:"added,omitempty"`
+	Removed []Func   `json:"removed,omitempty"`
+	Changed []Change `json:"changed,omitempty"`
+}
+
+// Change is a method present in both method sets whose canonical signature
+// differs between them.
+type Change struct {
+	Old Func `json:"old"`
+	New Func `json:"new"`
+}
+
+// Breaking reports whether the changes in r would break callers: any
+// removal or signature change is breaking, additions are not.
+func (r Report) Breaking() bool {
+	return len(r.Removed) > 0 || len(r.Changed) > 0
+}
+
+// String renders r the way `interfaces diff` prints it on the command
+// line: one line per delta, "+" for added, "-" for removed, "~" for
+// changed.
+func (r Report) String() string {
+	var buf strings.Builder
+	for _, f := range r.Added {
+		fmt.Fprintf(&buf, "+ %s\n", f)
+	}
+	for _, f := range r.Removed {
+		fmt.Fprintf(&buf, "- %s\n", f)
+	}
+	for _, c := range r.Changed {
+		fmt.Fprintf(&buf, "~ %s\n  -> %s\n", c.Old, c.New)
+	}
+	return buf.String()
+}
+
+// Diff compares the old and new method sets and classifies every method by
+// name: present only in new is Added, present only in old is Removed, and
+// present in both with a different canonical signature (Func.String) is
+// Changed. The result is sorted by method name.
+func Diff(old, new []Func) Report {
+	oldByName := make(map[string]Func, len(old))
+	for _, f := range old {
+		oldByName[f.Name] = f
+	}
+	newByName := make(map[string]Func, len(new))
+	for _, f := range new {
+		newByName[f.Name] = f
+	}
+
+	var report Report
+	for name, nf := range newByName {
+		of, ok := oldByName[name]
+		if !ok {
+			report.Added = append(report.Added, nf)
+			continue
+		}
+		if of.String() != nf.String() {
+			report.Changed = append(report.Changed, Change{Old: of, New: nf})
+		}
+	}
+	for name, of := range oldByName {
+		if _, ok := newByName[name]; !ok {
+			report.Removed = append(report.Removed, of)
+		}
+	}
+
+	sort.Sort(funcs(report.Added))
+	sort.Sort(funcs(report.Removed))
+	sort.Slice(report.Changed, func(i, j int) bool {
+		return report.Changed[i].Old.Name < report.Changed[j].Old.Name
+	})
+	return report
+}
+
+// LoadBaseline reads a baseline file written by WriteBaseline: one
+// canonical method signature (Func.String) per line. The returned Funcs
+// only have Name and Raw populated - enough to compare against a freshly
+// generated Interface's Funcs with Diff.
+func LoadBaseline(path string) ([]Func, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("interfaces: loading baseline %s: %w", path, err)
+	}
+	defer f.Close()
+	return parseBaseline(f)
+}
+
+func parseBaseline(r io.Reader) ([]Func, error) {
+	var funcs []Func
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, "(")
+		if i == -1 {
+			return nil, fmt.Errorf("interfaces: invalid baseline line %q: missing (", line)
+		}
+		funcs = append(funcs, Func{Name: line[:i], Raw: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("interfaces: reading baseline: %w", err)
+	}
+	return funcs, nil
+}
+
+// WriteBaseline writes funcs to path as one canonical signature per line,
+// sorted by method name, so that the file it produces is stable across
+// regenerations and diffs cleanly in version control.
+func WriteBaseline(path string, fns []Func) error {
+	sorted := append(funcs(nil), funcs(fns)...)
+	sort.Sort(sorted)
+
+	var buf strings.Builder
+	for _, f := range sorted {
+		fmt.Fprintln(&buf, f.String())
+	}
+
+	if err := os.WriteFile(path, []byte(buf.String()), 0o644); err != nil {
+		return fmt.Errorf("interfaces: writing baseline %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package interfaces
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	old := []Func{
+		{Name: "Get", Raw: "Get(int) string"},
+		{Name: "Close", Raw: "Close() error"},
+	}
+	new := []Func{
+		{Name: "Get", Raw: "Get(int) (string, error)"},
+		{Name: "Set", Raw: "Set(int, string)"},
+	}
+
+	report := Diff(old, new)
+
+	if len(report.Added) != 1 || report.Added[0].Name != "Set" {
+		t.Errorf("Added = %v, want [Set]", report.Added)
+	}
+	if len(report.Removed) != 1 || report.Removed[0].Name != "Close" {
+		t.Errorf("Removed = %v, want [Close]", report.Removed)
+	}
+	if len(report.Changed) != 1 || report.Changed[0].Old.Name != "Get" {
+		t.Errorf("Changed = %v, want [Get]", report.Changed)
+	}
+	if !report.Breaking() {
+		t.Error("Breaking() = false, want true")
+	}
+}
+
+func TestDiffNotBreaking(t *testing.T) {
+	old := []Func{{Name: "Get", Raw: "Get(int) string"}}
+	new := []Func{{Name: "Get", Raw: "Get(int) string"}, {Name: "Set", Raw: "Set(int, string)"}}
+
+	if report := Diff(old, new); report.Breaking() {
+		t.Errorf("Breaking() = true for an additive-only diff: %+v", report)
+	}
+}
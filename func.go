@@ -13,12 +13,21 @@ type Func struct {
 	Ins        []Type `json:"ins,omitempty"`  // input parameters
 	Outs       []Type `json:"outs,omitempty"` // output parameters
 	IsVariadic bool   // whether the function is variadic
+
+	// Raw, when non-empty, is used verbatim by String instead of
+	// reassembling Ins/Outs. It's set by LoadBaseline, which only has the
+	// canonical signature text to work with, not the original go/types
+	// values.
+	Raw string `json:"-"`
 }
 
 var variadic = strings.NewReplacer("[]", "...")
 
 // String gives Go code representation of the function.
 func (f Func) String() string {
+	if f.Raw != "" {
+		return f.Raw
+	}
 	var buf bytes.Buffer
 	if len(f.Ins) == 0 {
 		fmt.Fprintf(&buf, "%s()", f.Name)
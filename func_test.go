@@ -0,0 +1,67 @@
+package interfaces
+
+import "testing"
+
+func TestFuncString(t *testing.T) {
+	cases := []struct {
+		name string
+		f    Func
+		want string
+	}{
+		{
+			name: "no args no return",
+			f:    Func{Name: "Close"},
+			want: "Close()",
+		},
+		{
+			name: "single return",
+			f:    Func{Name: "Err", Outs: []Type{{Name: "error"}}},
+			want: "Err() error",
+		},
+		{
+			name: "multiple returns",
+			f: Func{
+				Name: "Get",
+				Ins:  []Type{{Name: "string"}},
+				Outs: []Type{{Name: "string"}, {Name: "error"}},
+			},
+			want: "Get(string) (string, error)",
+		},
+		{
+			name: "variadic",
+			f: Func{
+				Name:       "Do",
+				Ins:        []Type{{Name: "[]string", IsComposite: true}},
+				IsVariadic: true,
+			},
+			want: "Do(...string)",
+		},
+		{
+			name: "raw takes precedence",
+			f:    Func{Name: "Get", Raw: "Get(int) string"},
+			want: "Get(int) string",
+		},
+	}
+	for _, c := range cases {
+		if got := c.f.String(); got != c.want {
+			t.Errorf("%s: String() = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestFuncDeps(t *testing.T) {
+	f := Func{
+		Ins:  []Type{{ImportPath: "net/http", Deps: []string{"io"}}},
+		Outs: []Type{{ImportPath: "net"}},
+	}
+	want := []string{"io", "net", "net/http"}
+	got := f.Deps()
+	if len(got) != len(want) {
+		t.Fatalf("Deps() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Deps()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
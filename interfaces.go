@@ -0,0 +1,245 @@
+package interfaces
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Query identifies the concrete type to generate an interface from, given
+// as the import path of its package and its name within that package, e.g.
+// Query{Package: "github.com/go-redis/redis", TypeName: "Client"}.
+type Query struct {
+	Package  string   // import path of the package the type is defined in
+	TypeName string   // name of the type within the package
+	TypeArgs []string // instantiate a generic type with these type arguments instead of emitting a generic interface
+
+	// PreserveAliases overrides Options.PreserveAliases for this Query
+	// alone; nil means "use the Options value".
+	PreserveAliases *bool
+}
+
+// String gives back the canonical "pkg.Type" representation of q.
+func (q Query) String() string {
+	return q.Package + "." + q.TypeName
+}
+
+// ParseQuery parses a "path/to/package.TypeName" string into a Query.
+func ParseQuery(s string) (q Query, err error) {
+	i := strings.LastIndex(s, ".")
+	if i == -1 {
+		return q, fmt.Errorf("interfaces: invalid query %q: missing type name", s)
+	}
+	q.Package, q.TypeName = s[:i], s[i+1:]
+	if q.Package == "" || q.TypeName == "" {
+		return q, fmt.Errorf("interfaces: invalid query %q", s)
+	}
+	return q, nil
+}
+
+// resolveTypeArg resolves a single entry of Query.TypeArgs - either a
+// predeclared basic type name ("string", "int", ...) or a "path/to/package.Type"
+// query - to the types.Type types.Instantiate needs to instantiate a
+// generic named type with it.
+func resolveTypeArg(s string, loader Loader) (types.Type, error) {
+	if obj := types.Universe.Lookup(s); obj != nil {
+		if tn, ok := obj.(*types.TypeName); ok {
+			return tn.Type(), nil
+		}
+	}
+
+	q, err := ParseQuery(s)
+	if err != nil {
+		return nil, fmt.Errorf("interfaces: invalid type argument %q: %w", s, err)
+	}
+
+	if loader == nil {
+		loader = packagesLoader{}
+	}
+	pkgs, err := loader.Load(q.Package)
+	if err != nil {
+		return nil, fmt.Errorf("interfaces: loading type argument package %q: %w", q.Package, err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("interfaces: package %q not found", q.Package)
+	}
+
+	obj := pkgs[0].Types.Scope().Lookup(q.TypeName)
+	if obj == nil {
+		return nil, fmt.Errorf("interfaces: type %q not found in %q", q.TypeName, q.Package)
+	}
+	tn, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("interfaces: %q is not a type", q.TypeName)
+	}
+	return tn.Type(), nil
+}
+
+// Options control how an Interface is generated for a Query.
+type Options struct {
+	Query       Query             // concrete type to generate the interface from
+	PackageName string            // name of the package the interface will be written to
+	Aliases     map[string]string // import path -> local alias bindings, e.g. from a config's models map
+
+	// PreserveAliases keeps `type X = Y` identity in generated signatures,
+	// e.g. rendering "generic.MyGenericAlias" instead of expanding it to
+	// "generic.MyGeneric[util.MyUtil]". Override it for a single Query via
+	// Query.PreserveAliases.
+	PreserveAliases bool
+
+	// Loader loads the source type's package and the packages type
+	// arguments found in its method set are defined in. Defaults to a
+	// Loader backed by golang.org/x/tools/go/packages; set it to inject a
+	// pre-warmed set of packages and avoid `go list` calls. A type argument
+	// whose package the pre-pass did not collect is still loaded with
+	// packages.Load directly.
+	Loader Loader
+}
+
+// Interface is a generated interface type: its name, the type parameters it
+// was generated with (if the source type is generic) and the set of
+// methods promoted from the concrete type's method set.
+type Interface struct {
+	Name       string      `json:"name,omitempty"`
+	TypeParams []TypeParam `json:"typeParams,omitempty"`
+	Funcs      []Func      `json:"funcs,omitempty"`
+}
+
+// String gives the Go code representation of the interface declaration.
+func (i Interface) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "type %s%sinterface {\n", i.Name, i.typeParamsSuffix())
+	for _, f := range i.Funcs {
+		fmt.Fprintf(&buf, "\t%s\n", f.String())
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+func (i Interface) typeParamsSuffix() string {
+	if len(i.TypeParams) == 0 {
+		return " "
+	}
+	names := make([]string, len(i.TypeParams))
+	for j, tp := range i.TypeParams {
+		names[j] = tp.String()
+	}
+	return "[" + strings.Join(names, ", ") + "] "
+}
+
+// NewInterface builds an Interface from the method set of the concrete
+// type identified by opts.Query.
+//
+// If the target type is generic and opts.Query.TypeArgs is empty, the
+// returned Interface is itself generic: its methods re-use the source
+// type's type parameters rather than instantiating them. Set
+// opts.Query.TypeArgs to request a concrete, instantiated variant instead,
+// e.g. []string{"github.com/rjeczalik/interfaces/testdata/util.MyUtil"} for
+// MyGeneric[util.MyUtil].
+func NewInterface(opts *Options) (*Interface, error) {
+	loader := opts.Loader
+	if loader == nil {
+		loader = packagesLoader{}
+	}
+
+	pkgs, err := loader.Load(opts.Query.Package)
+	if err != nil {
+		return nil, fmt.Errorf("interfaces: loading %q: %w", opts.Query.Package, err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("interfaces: package %q not found", opts.Query.Package)
+	}
+	pkg := pkgs[0]
+
+	obj := pkg.Types.Scope().Lookup(opts.Query.TypeName)
+	if obj == nil {
+		return nil, fmt.Errorf("interfaces: type %q not found in %q", opts.Query.TypeName, opts.Query.Package)
+	}
+	typeName, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("interfaces: %q is not a type", opts.Query.TypeName)
+	}
+	named, ok := typeName.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("interfaces: %q is not a named type", opts.Query.TypeName)
+	}
+
+	iface := &Interface{
+		Name: opts.Query.TypeName + "er",
+	}
+
+	var tps typeParams
+	switch {
+	case named.TypeParams().Len() > 0 && len(opts.Query.TypeArgs) > 0:
+		// Generic source type, explicit instantiation requested: resolve
+		// each type argument to a types.Type and instantiate named with
+		// them, so the interface's methods are generated against the
+		// concrete signatures (e.g. Get(int) util.MyUtil) instead of the
+		// generic ones.
+		argTypes := make([]types.Type, len(opts.Query.TypeArgs))
+		for i, arg := range opts.Query.TypeArgs {
+			argType, err := resolveTypeArg(arg, opts.Loader)
+			if err != nil {
+				return nil, err
+			}
+			argTypes[i] = argType
+		}
+		inst, err := types.Instantiate(nil, named, argTypes, true)
+		if err != nil {
+			return nil, fmt.Errorf("interfaces: instantiating %s[%s]: %w", opts.Query, strings.Join(opts.Query.TypeArgs, ", "), err)
+		}
+		named = inst.(*types.Named)
+	case named.TypeParams().Len() > 0:
+		// Generic source type, no explicit instantiation requested: keep
+		// the interface generic and render its methods in terms of the
+		// source's own type parameters.
+		tps = typeParamsOf(named.TypeParams())
+		iface.TypeParams = make([]TypeParam, named.TypeParams().Len())
+		for i := 0; i < named.TypeParams().Len(); i++ {
+			iface.TypeParams[i] = tps[named.TypeParams().At(i)]
+		}
+	}
+
+	if err := warmPackageCache(named, opts.Loader); err != nil {
+		return nil, fmt.Errorf("interfaces: warming package cache: %w", err)
+	}
+
+	q := newQualifier(opts.PackageName, opts.Aliases)
+
+	preserveAliases := opts.PreserveAliases
+	if opts.Query.PreserveAliases != nil {
+		preserveAliases = *opts.Query.PreserveAliases
+	}
+
+	mset := types.NewMethodSet(types.NewPointer(named))
+	funcs := make(funcs, 0, mset.Len())
+	for i := 0; i < mset.Len(); i++ {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+		funcs = append(funcs, newFunc(fn, q, tps, preserveAliases))
+	}
+	sort.Sort(funcs)
+	iface.Funcs = []Func(funcs)
+
+	return iface, nil
+}
+
+func newFunc(fn *types.Func, q types.Qualifier, tps typeParams, preserveAliases bool) Func {
+	sig := fn.Type().(*types.Signature)
+
+	f := Func{
+		Name:       fn.Name(),
+		IsVariadic: sig.Variadic(),
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		f.Ins = append(f.Ins, newQualifiedType(sig.Params().At(i), q, tps, preserveAliases))
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		f.Outs = append(f.Outs, newQualifiedType(sig.Results().At(i), q, tps, preserveAliases))
+	}
+	return f
+}
@@ -0,0 +1,74 @@
+package interfaces
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewInterfaceGeneric(t *testing.T) {
+	iface, err := NewInterface(&Options{
+		Query: Query{
+			Package:  "github.com/rjeczalik/interfaces/testdata/generic",
+			TypeName: "MyGeneric",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(iface.TypeParams) != 1 || iface.TypeParams[0].String() != "A any" {
+		t.Fatalf("TypeParams = %v, want [A any]", iface.TypeParams)
+	}
+	if !strings.Contains(iface.String(), "Get(int) A") {
+		t.Errorf("String() = %q, want it to contain %q", iface.String(), "Get(int) A")
+	}
+}
+
+func TestNewInterfaceInstantiated(t *testing.T) {
+	iface, err := NewInterface(&Options{
+		Query: Query{
+			Package:  "github.com/rjeczalik/interfaces/testdata/generic",
+			TypeName: "MyGeneric",
+			TypeArgs: []string{"github.com/rjeczalik/interfaces/testdata/util.MyUtil"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(iface.TypeParams) != 0 {
+		t.Fatalf("TypeParams = %v, want none for an instantiated interface", iface.TypeParams)
+	}
+	if !strings.Contains(iface.String(), "Get(int) util.MyUtil") {
+		t.Errorf("String() = %q, want it to contain %q", iface.String(), "Get(int) util.MyUtil")
+	}
+}
+
+func TestNewInterfacePreserveAliases(t *testing.T) {
+	iface, err := NewInterface(&Options{
+		Query: Query{
+			Package:  "github.com/rjeczalik/interfaces/testdata/generic",
+			TypeName: "MyGeneric",
+			TypeArgs: []string{"github.com/rjeczalik/interfaces/testdata/util.MyUtil"},
+		},
+		Aliases:         map[string]string{"github.com/rjeczalik/interfaces/testdata/util": "u"},
+		PreserveAliases: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(iface.String(), "GetUtil() generic.MyGenericAlias") {
+		t.Errorf("String() = %q, want it to contain %q", iface.String(), "GetUtil() generic.MyGenericAlias")
+	}
+
+	var aliased *Type
+	for _, f := range iface.Funcs {
+		if f.Name == "GetUtil" {
+			aliased = f.Outs[0].Aliased
+		}
+	}
+	if aliased == nil {
+		t.Fatal("GetUtil's return type carried a nil Aliased")
+	}
+	if got, want := aliased.String(), "generic.MyGeneric[u.MyUtil]"; got != want {
+		t.Errorf("Aliased.String() = %q, want %q (qualified with the same Aliases map as the rest of the interface)", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package interfaces
+
+import (
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Loader loads the Go packages needed to generate an interface: the source
+// type's own package and the packages of the type arguments that occur in
+// its method set. Implement it to hand back an
+// already-loaded, pre-warmed set of packages and avoid invoking `go list`.
+type Loader interface {
+	Load(patterns ...string) ([]*packages.Package, error)
+}
+
+// packagesLoader is the default Loader; it wraps packages.Load.
+type packagesLoader struct{}
+
+func (packagesLoader) Load(patterns ...string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode:  packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps | packages.NeedName,
+		Tests: true,
+	}
+	return packages.Load(cfg, patterns...)
+}
+
+// warmPackageCache collects every distinct package path named's method set
+// references as a type argument, loads them all in one Loader.Load call,
+// and seeds packageCache with the result.
+func warmPackageCache(named *types.Named, loader Loader) error {
+	if loader == nil {
+		loader = packagesLoader{}
+	}
+
+	paths := collectPackagePaths(named)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	pkgs, err := loader.Load(paths...)
+	if err != nil {
+		return err
+	}
+
+	packageMutex.Lock()
+	defer packageMutex.Unlock()
+	for _, pkg := range pkgs {
+		packageCache[pkg.PkgPath] = pkg
+	}
+	return nil
+}
+
+// collectPackagePaths walks every method in named's method set looking for
+// type arguments of named types, returning the sorted, deduplicated set of
+// packages they're defined in.
+func collectPackagePaths(named *types.Named) []string {
+	seen := make(map[string]bool)
+
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < mset.Len(); i++ {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		for j := 0; j < sig.Params().Len(); j++ {
+			collectPackagePathsOf(sig.Params().At(j).Type(), seen, 0)
+		}
+		for j := 0; j < sig.Results().Len(); j++ {
+			collectPackagePathsOf(sig.Results().At(j).Type(), seen, 0)
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func collectPackagePathsOf(t types.Type, seen map[string]bool, depth int) {
+	if depth > 128 {
+		return
+	}
+	switch t := t.(type) {
+	case *types.Named:
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			arg := t.TypeArgs().At(i)
+			if named, ok := arg.(interface{ Obj() *types.TypeName }); ok {
+				if pkg := named.Obj().Pkg(); pkg != nil {
+					seen[pkg.Path()] = true
+				}
+			}
+			collectPackagePathsOf(arg, seen, depth+1)
+		}
+	case *types.Alias:
+		collectPackagePathsOf(types.Unalias(t), seen, depth+1)
+	case *types.Pointer:
+		collectPackagePathsOf(t.Elem(), seen, depth+1)
+	case *types.Map:
+		collectPackagePathsOf(t.Key(), seen, depth+1)
+		collectPackagePathsOf(t.Elem(), seen, depth+1)
+	case compositeType:
+		collectPackagePathsOf(t.Elem(), seen, depth+1)
+	}
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import "go/types"
+
+// newQualifier returns a types.Qualifier suited to interfacer's output: the
+// output package itself is left unqualified, vendored import paths are
+// trimmed, and any import alias from aliases is honoured instead of the
+// package's own name.
+func newQualifier(outputPkg string, aliases map[string]string) types.Qualifier {
+	return func(pkg *types.Package) string {
+		if pkg == nil {
+			return ""
+		}
+		path := trimVendorPath(pkg.Path())
+		if pkg.Name() == outputPkg {
+			return ""
+		}
+		if alias, ok := aliases[path]; ok {
+			return alias
+		}
+		return pkg.Name()
+	}
+}
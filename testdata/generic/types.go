@@ -8,6 +8,22 @@ type MyGeneric[A any] struct {
 	Value []A
 }
 
+// Get returns the element at i.
+func (m *MyGeneric[A]) Get(i int) A {
+	return m.Value[i]
+}
+
+// Add appends v to the underlying slice.
+func (m *MyGeneric[A]) Add(v A) {
+	m.Value = append(m.Value, v)
+}
+
+// GetUtil returns the zero value of MyGenericAlias, exercising alias
+// rendering in a method signature.
+func (m *MyGeneric[A]) GetUtil() MyGenericAlias {
+	return MyGenericAlias{}
+}
+
 type MyGenericAlias = MyGeneric[util.MyUtil]
 
 type MyGenericAliasWithTypeArg[B any] = MyGeneric[B]
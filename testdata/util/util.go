@@ -0,0 +1,8 @@
+// Package util provides a fixture type for the generic and alias handling
+// tests in testdata/generic.
+package util
+
+// MyUtil is a concrete type used as a type argument of MyGeneric.
+type MyUtil struct {
+	Name string
+}
@@ -19,10 +19,57 @@ type Type struct {
 	IsPointer   bool     `json:"isPointer,omitempty"`   // whether the parameter is a pointer
 	IsComposite bool     `json:"isComposite,omitempty"` // whether the type is map, slice, chan or array
 	IsFunc      bool     `json:"isFunc,omitempty"`      // whether the type if function
+	IsTypeParam bool     `json:"isTypeParam,omitempty"` // whether the type is a type parameter of the enclosing generic type
+	Qualified   bool     `json:"qualified,omitempty"`   // whether Name is already a fully rendered Go expression (see newQualifiedType)
+	Aliased     *Type    `json:"aliased,omitempty"`     // with Options.PreserveAliases, what this type's *types.Alias resolves to
 }
 
-// String gives Go code representation of the type.
+// TypeParam represents a single type parameter of a generic type, e.g. the
+// A in MyGeneric[A any].
+type TypeParam struct {
+	Name       string `json:"name,omitempty"`       // type parameter name, e.g. "A"
+	Constraint string `json:"constraint,omitempty"` // constraint, e.g. "any"
+}
+
+// String gives the Go code representation of the type parameter, as it
+// appears between the brackets of a generic declaration.
+func (tp TypeParam) String() string {
+	return tp.Name + " " + tp.Constraint
+}
+
+// typeParams maps the *types.TypeParam of a generic declaration to the
+// TypeParam it was rendered from, so that occurrences of the same type
+// parameter in method signatures are rendered using its original name
+// instead of being instantiated.
+type typeParams map[*types.TypeParam]TypeParam
+
+// typeParamsOf builds a typeParams map from the type parameter list of a
+// generic *types.Named. It returns nil if tp is nil or empty, so callers
+// can pass it straight through setFromType without a nil check.
+func typeParamsOf(tp *types.TypeParamList) typeParams {
+	if tp == nil || tp.Len() == 0 {
+		return nil
+	}
+	tps := make(typeParams, tp.Len())
+	for i := 0; i < tp.Len(); i++ {
+		p := tp.At(i)
+		tps[p] = TypeParam{
+			Name:       p.Obj().Name(),
+			Constraint: p.Constraint().String(),
+		}
+	}
+	return tps
+}
+
+// String gives Go code representation of the type. For types built by
+// newQualifiedType, Name is already a complete, correctly qualified Go
+// expression (produced by types.TypeString), so String is a thin adapter
+// that returns it as-is; for the legacy, depth-walked Type values it falls
+// back to assembling the pointer and package prefixes itself.
 func (typ Type) String() (s string) {
+	if typ.Qualified {
+		return typ.Name
+	}
 	if typ.IsPointer {
 		s = "*"
 	}
@@ -32,29 +79,30 @@ func (typ Type) String() (s string) {
 	return s + typ.Name
 }
 
-var (
-	typeCache = make(map[string]Type)
-	// This mutex isn't 100% necessary, but it makes me feel better having it to ensure no race conditions.
-	typeCacheMutex sync.RWMutex
-)
-
-func newType(v *types.Var) (typ Type) {
-	key := v.Type().String()
-
-	typeCacheMutex.RLock()
-	typ, ok := typeCache[key]
-	typeCacheMutex.RUnlock()
-
-	if ok {
-		return typ
+// newQualifiedType builds a Type whose Name is rendered with
+// types.TypeString and q rather than by walking t depth-first and patching
+// the result with string replacements. Since go/types itself walks nested
+// composites, function-typed fields and generic instantiations, this
+// handles them correctly in one pass, without the depth==0-only pointer
+// heuristic (IsPointer) that the unqualified String path relies on to
+// decide whether to print a leading "*".
+//
+// The struct's other fields (Package, ImportPath, Deps, IsComposite,
+// IsFunc) are still populated by the regular setFromType walk, since
+// they're used for dependency tracking rather than rendering.
+//
+// t is only ever a *types.Alias if preserveAliases is set; otherwise it is
+// unaliased first, so that types.TypeString(t, q) - which prints whatever
+// concrete types.Type it's handed, alias or not - expands `type X = Y`
+// instead of rendering the alias's own name.
+func newQualifiedType(v *types.Var, q types.Qualifier, tps typeParams, preserveAliases bool) (typ Type) {
+	t := v.Type()
+	if !preserveAliases {
+		t = types.Unalias(t)
 	}
-
-	typ.setFromType(v.Type(), 0, nil)
-
-	typeCacheMutex.Lock()
-	typeCache[key] = typ
-	typeCacheMutex.Unlock()
-
+	typ.setFromType(t, 0, nil, q, tps, preserveAliases)
+	typ.Name = types.TypeString(t, q)
+	typ.Qualified = true
 	return typ
 }
 
@@ -63,7 +111,7 @@ type compositeType interface {
 	Elem() types.Type
 }
 
-func (typ *Type) setFromType(t types.Type, depth int, orig types.Type) {
+func (typ *Type) setFromType(t types.Type, depth int, orig types.Type, q types.Qualifier, tps typeParams, preserveAliases bool) {
 	if orig == nil {
 		orig = t
 	}
@@ -71,6 +119,8 @@ func (typ *Type) setFromType(t types.Type, depth int, orig types.Type) {
 		panic("recursive types not supported: " + orig.String())
 	}
 	switch t := t.(type) {
+	case *types.TypeParam:
+		typ.setFromTypeParam(t, tps)
 	case *types.Basic:
 		typ.setFromBasic(t)
 	case *types.Interface:
@@ -78,7 +128,7 @@ func (typ *Type) setFromType(t types.Type, depth int, orig types.Type) {
 	case *types.Struct:
 		typ.setFromStruct(t)
 	case *types.Named:
-		typ.setFromNamedObject(t)
+		typ.setFromNamedObject(t, tps)
 	case *types.Signature:
 		typ.IsFunc = true
 		typ.setFromSignature(t)
@@ -86,19 +136,64 @@ func (typ *Type) setFromType(t types.Type, depth int, orig types.Type) {
 		if depth == 0 {
 			typ.IsPointer = true
 		}
-		typ.setFromType(t.Elem(), depth+1, orig)
+		typ.setFromType(t.Elem(), depth+1, orig, q, tps, preserveAliases)
 	case *types.Map:
-		typ.setFromComposite(t, depth, orig)
-		typ.setFromType(t.Key(), depth+1, orig)
+		typ.setFromComposite(t, depth, orig, q, tps, preserveAliases)
+		typ.setFromType(t.Key(), depth+1, orig, q, tps, preserveAliases)
 	case *types.Alias:
-		typ.setFromNamedObject(t)
+		if preserveAliases {
+			typ.setFromAlias(t, q, tps, preserveAliases)
+		} else {
+			typ.setFromNamedObject(t, tps)
+		}
 	case compositeType:
-		typ.setFromComposite(t, depth, orig)
+		typ.setFromComposite(t, depth, orig, q, tps, preserveAliases)
 	default:
 		panic(fmt.Sprintf("internal: t=%T, orig=%T", t, orig))
 	}
 }
 
+// setFromAlias records t's own TypeName and package rather than recursing
+// into the type it aliases, so that e.g. MyGenericAlias = MyGeneric[util.MyUtil]
+// renders as "generic.MyGenericAlias" instead of "generic.MyGeneric[util.MyUtil]".
+// The aliased type is rendered through q - the same qualifier the top-level
+// Type was rendered with - rather than a raw setFromType walk, so Aliased
+// comes out qualified consistently with the rest of the output; its Deps
+// still fold into typ.Deps so dependency tracking and import collection
+// stay correct.
+func (typ *Type) setFromAlias(t *types.Alias, q types.Qualifier, tps typeParams, preserveAliases bool) {
+	typ.Name = t.Obj().Name()
+	typ.setFromTypeArgs(t.TypeArgs(), tps)
+	if pkg := t.Obj().Pkg(); pkg != nil {
+		typ.Package = pkg.Name()
+		typ.ImportPath = pkg.Path()
+	}
+
+	aliasedType := types.Unalias(t)
+	var aliased Type
+	aliased.setFromType(aliasedType, 0, nil, q, tps, preserveAliases)
+	aliased.Name = types.TypeString(aliasedType, q)
+	aliased.Qualified = true
+	typ.Aliased = &aliased
+
+	typ.Deps = append(typ.Deps, aliased.Deps...)
+	if aliased.ImportPath != "" {
+		typ.Deps = append(typ.Deps, aliased.ImportPath)
+	}
+}
+
+// setFromTypeParam renders a *types.TypeParam as the name it was declared
+// with in the enclosing generic type, e.g. the A in MyGeneric[A any],
+// rather than instantiating it.
+func (typ *Type) setFromTypeParam(t *types.TypeParam, tps typeParams) {
+	typ.IsTypeParam = true
+	if tp, ok := tps[t]; ok {
+		typ.Name = tp.Name
+		return
+	}
+	typ.Name = t.Obj().Name()
+}
+
 func (typ *Type) setFromBasic(t *types.Basic) {
 	if typ.Name == "" {
 		typ.Name = t.Name()
@@ -131,10 +226,10 @@ type NamedType interface {
 	TypeArgs() *types.TypeList
 }
 
-func (typ *Type) setFromNamedObject(t NamedType) {
+func (typ *Type) setFromNamedObject(t NamedType, tps typeParams) {
 	if typ.Name == "" {
 		typ.Name = t.Obj().Name()
-		typ.setFromTypeArgs(t.TypeArgs())
+		typ.setFromTypeArgs(t.TypeArgs(), tps)
 	}
 
 	if typ.Package != "" || typ.ImportPath != "" {
@@ -152,7 +247,7 @@ var (
 	packageMutex sync.RWMutex
 )
 
-func (typ *Type) setFromTypeArgs(typeArgs *types.TypeList) {
+func (typ *Type) setFromTypeArgs(typeArgs *types.TypeList, tps typeParams) {
 	if typeArgs == nil || typeArgs.Len() == 0 {
 		return
 	}
@@ -167,6 +262,15 @@ func (typ *Type) setFromTypeArgs(typeArgs *types.TypeList) {
 			// We don't need to add any dependencies for the basic types.
 			argValues[i] = t.Name()
 			continue
+		case *types.TypeParam:
+			// The type argument is itself a type parameter of the enclosing
+			// generic declaration (e.g. MyGenericAliasWithTypeArg[B any] =
+			// MyGeneric[B]) - render it by name instead of resolving it to a
+			// package.
+			var tp Type
+			tp.setFromTypeParam(t, tps)
+			argValues[i] = tp.Name
+			continue
 		}
 
 		tString := typeArg.String()
@@ -207,49 +311,12 @@ func (typ *Type) setFromTypeArgs(typeArgs *types.TypeList) {
 	typ.Name = fmt.Sprintf("%s[%s]", typ.Name, strings.Join(argValues, ", "))
 }
 
-func (typ *Type) setFromComposite(t compositeType, depth int, orig types.Type) {
+func (typ *Type) setFromComposite(t compositeType, depth int, orig types.Type, q types.Qualifier, tps typeParams, preserveAliases bool) {
 	typ.IsComposite = true
 	if typ.Name == "" {
 		typ.Name = t.String()
 	}
-	typ.setFromType(t.Elem(), depth+1, orig)
-}
-
-func fixup(typ *Type, opts *Options) {
-	query := opts.Query
-	packageName := opts.PackageName
-
-	// Hacky fixup for renaming:
-	//
-	//   GeoAdd(string, []*github.com/go-redis/redis.GeoLocation) *redis.IntCmd
-	//
-	// to:
-	//
-	//   GeoAdd(string, []*redis.GeoLocation) *redis.IntCmd
-	//
-	// Should be fixed layer below, in type.go.
-
-	// when include other package struct
-	if typ.ImportPath != "" && typ.IsComposite {
-		if typ.ImportPath == query.Package {
-			typ.Name = strings.Replace(typ.Name, typ.ImportPath, typ.Package, -1)
-		}
-
-		if typ.ImportPath != query.Package {
-			pkgIdx := strings.LastIndex(typ.ImportPath, typ.Package)
-			if 0 < pkgIdx {
-				typ.Name = strings.Replace(typ.Name, typ.ImportPath[:pkgIdx], "", -1)
-			}
-		}
-	}
-
-	typ.Name = strings.Replace(typ.Name, query.Package, path.Base(query.Package), -1)
-	typ.ImportPath = trimVendorPath(typ.ImportPath)
-
-	if typ.Package == packageName {
-		typ.Package = ""
-		typ.ImportPath = ""
-	}
+	typ.setFromType(t.Elem(), depth+1, orig, q, tps, preserveAliases)
 }
 
 // trimVendorPath removes the vendor dir prefix from a package path.
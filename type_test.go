@@ -0,0 +1,40 @@
+package interfaces
+
+import "testing"
+
+func TestTypeString(t *testing.T) {
+	cases := []struct {
+		name string
+		typ  Type
+		want string
+	}{
+		{"qualified", Type{Name: "redis.Client", Qualified: true}, "redis.Client"},
+		{"pointer", Type{Name: "Client", Package: "redis", IsPointer: true}, "*redis.Client"},
+		{"builtin", Type{Name: "string"}, "string"},
+		{"composite", Type{Name: "[]redis.Client", IsComposite: true}, "[]redis.Client"},
+	}
+	for _, c := range cases {
+		if got := c.typ.String(); got != c.want {
+			t.Errorf("%s: String() = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestTypeParamString(t *testing.T) {
+	tp := TypeParam{Name: "A", Constraint: "any"}
+	if got, want := tp.String(), "A any"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestTrimVendorPath(t *testing.T) {
+	cases := map[string]string{
+		"github.com/foo/bar/vendor/github.com/pkg/errors": "github.com/pkg/errors",
+		"github.com/pkg/errors":                           "github.com/pkg/errors",
+	}
+	for in, want := range cases {
+		if got := trimVendorPath(in); got != want {
+			t.Errorf("trimVendorPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}